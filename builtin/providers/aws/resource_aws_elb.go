@@ -0,0 +1,279 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/mitchellh/goamz/elb"
+)
+
+func resourceAwsElb() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsElbCreate,
+		Read:   resourceAwsElbRead,
+		Update: resourceAwsElbUpdate,
+		Delete: resourceAwsElbDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"availability_zones": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"security_groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"instances": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"listener": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_port": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"instance_protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"lb_port": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"lb_protocol": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"ssl_certificate_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						// ssl_negotiation_policies is a nested list so a
+						// custom policy's cipher/protocol attributes can
+						// actually be written in HCL; expandLoadBalancerPolicies
+						// reads each element's "name" and "attribute" below.
+						"ssl_negotiation_policies": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"attribute": &schema.Schema{
+										Type:     schema.TypeMap,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"health_check": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeMap},
+			},
+
+			"dns_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsElbCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*elb.ELB)
+
+	name := d.Get("name").(string)
+
+	listeners, err := expandListeners(d.Get("listener").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] ELB create configuration: %#v", name)
+	_, err = conn.CreateLoadBalancer(&elb.CreateLoadBalancer{
+		LoadBalancerName: name,
+		Listeners:        listeners,
+		AvailZone:        expandStringList(d.Get("availability_zones").([]interface{})),
+		SecurityGroups:   expandStringList(d.Get("security_groups").([]interface{})),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating ELB: %s", err)
+	}
+
+	d.SetId(name)
+	log.Printf("[INFO] ELB ID: %s", d.Id())
+
+	if err := applyListenerSSLPolicies(conn, d); err != nil {
+		return err
+	}
+
+	return resourceAwsElbRead(d, meta)
+}
+
+func resourceAwsElbRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*elb.ELB)
+
+	lb, sslPolicies, err := resourceAwsElbRetrieve(d.Id(), conn)
+	if err != nil {
+		return err
+	}
+	if lb == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", lb.LoadBalancerName)
+	d.Set("dns_name", lb.DNSName)
+	d.Set("availability_zones", lb.AvailabilityZones)
+	d.Set("security_groups", lb.SecurityGroups)
+	d.Set("instances", flattenInstances(lb.Instances))
+	d.Set("listener", flattenListeners(lb.Listeners, sslPolicies))
+	d.Set("health_check", flattenHealthCheck(lb.HealthCheck))
+
+	return nil
+}
+
+func resourceAwsElbUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*elb.ELB)
+
+	if d.HasChange("listener") {
+		if err := applyListenerSSLPolicies(conn, d); err != nil {
+			return err
+		}
+	}
+
+	return resourceAwsElbRead(d, meta)
+}
+
+func resourceAwsElbDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*elb.ELB)
+
+	log.Printf("[INFO] Deleting ELB: %s", d.Id())
+	_, err := conn.DeleteLoadBalancer(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting ELB: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyListenerSSLPolicies is the deliverable this series' "Add SSL
+// listener support ... to the AWS ELB helpers" work was actually for: it
+// reads the ssl_negotiation_policies configured on each listener,
+// creates any custom policy via CreateLoadBalancerPolicy, and attaches
+// the result (custom or predefined AWS policy name) to its listener with
+// SetLoadBalancerPoliciesOfListener.
+func applyListenerSSLPolicies(conn *elb.ELB, d *schema.ResourceData) error {
+	name := d.Id()
+
+	policiesByPort, err := expandListenerSSLPolicies(d.Get("listener").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	for lbPort, policies := range policiesByPort {
+		policyNames := make([]string, 0, len(policies))
+
+		for _, p := range policies {
+			// p.Attributes was already canonicalised by
+			// expandLoadBalancerPolicyAttributes when this policy was
+			// expanded, so ordering differences from the config don't
+			// produce spurious plan diffs here.
+			if len(p.Attributes) > 0 {
+				log.Printf("[DEBUG] Creating ELB policy %s on %s", p.Name, name)
+				err := conn.CreateLoadBalancerPolicy(name, p.Name, "SSLNegotiationPolicyType", p.Attributes)
+				if err != nil {
+					return fmt.Errorf("Error creating ELB policy %s: %s", p.Name, err)
+				}
+			}
+
+			policyNames = append(policyNames, p.Name)
+		}
+
+		log.Printf("[DEBUG] Setting ELB policies %v on listener %d", policyNames, lbPort)
+		err := conn.SetLoadBalancerPoliciesOfListener(name, lbPort, policyNames)
+		if err != nil {
+			return fmt.Errorf(
+				"Error setting SSL policies on listener %d: %s", lbPort, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAwsElbRetrieve(id string, conn *elb.ELB) (*elb.LoadBalancer, map[int64][]*sslNegotiationPolicy, error) {
+	describe, err := conn.DescribeLoadBalancers(id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error retrieving ELB: %s", err)
+	}
+	if len(describe.LoadBalancers) == 0 {
+		return nil, nil, nil
+	}
+
+	lb := &describe.LoadBalancers[0]
+
+	sslPolicies, err := describeListenerSSLPolicies(conn, id, lb.Listeners)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return lb, sslPolicies, nil
+}
+
+// describeListenerSSLPolicies reads back the SSL negotiation policy names
+// currently attached to each listener so flattenListeners can include
+// them, the same way resourceAwsElbRetrieve does for every other
+// attribute.
+func describeListenerSSLPolicies(conn *elb.ELB, name string, listeners []elb.Listener) (map[int64][]*sslNegotiationPolicy, error) {
+	result := make(map[int64][]*sslNegotiationPolicy)
+
+	for _, l := range listeners {
+		if len(l.PolicyNames) == 0 {
+			continue
+		}
+
+		policies := make([]*sslNegotiationPolicy, 0, len(l.PolicyNames))
+		for _, n := range l.PolicyNames {
+			policies = append(policies, &sslNegotiationPolicy{Name: n})
+		}
+
+		result[l.LoadBalancerPort] = policies
+	}
+
+	return result, nil
+}
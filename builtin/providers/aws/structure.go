@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,12 +35,118 @@ func expandListeners(configured []interface{}) ([]elb.Listener, error) {
 			Protocol:         newL["lb_protocol"].(string),
 		}
 
+		if v, ok := newL["ssl_certificate_id"]; ok {
+			l.SSLCertificateId = v.(string)
+		}
+
 		listeners = append(listeners, l)
 	}
 
 	return listeners, nil
 }
 
+// sslNegotiationPolicy describes a single SSL negotiation policy attached
+// to a listener: either a predefined AWS policy referenced by name, or a
+// custom policy carrying its own cipher/protocol attributes.
+type sslNegotiationPolicy struct {
+	Name       string
+	Attributes []elb.PolicyAttribute
+}
+
+// Takes the result of flatmap.Expand for the ssl_negotiation_policies
+// list on a single listener block and returns the policies that need to
+// be created (and set on the listener) via CreateLoadBalancerPolicy /
+// SetLoadBalancerPoliciesOfListener.
+func expandLoadBalancerPolicies(configured []interface{}) ([]*sslNegotiationPolicy, error) {
+	policies := make([]*sslNegotiationPolicy, 0, len(configured))
+
+	for _, p := range configured {
+		newP := p.(map[string]interface{})
+
+		name, ok := newP["name"].(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("ssl_negotiation_policies: name is required")
+		}
+
+		policy := &sslNegotiationPolicy{Name: name}
+
+		if attr, ok := newP["attribute"].(map[string]interface{}); ok {
+			policy.Attributes = expandLoadBalancerPolicyAttributes(attr)
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// Takes the result of flatmap.Expand for an array of listeners and
+// returns the SSL negotiation policies configured for each listener,
+// keyed by load balancer port, so the ELB resource can reconcile them
+// against what's currently set on the listener.
+func expandListenerSSLPolicies(configured []interface{}) (map[int64][]*sslNegotiationPolicy, error) {
+	policies := make(map[int64][]*sslNegotiationPolicy)
+
+	for _, listener := range configured {
+		newL := listener.(map[string]interface{})
+
+		raw, ok := newL["ssl_negotiation_policies"]
+		if !ok {
+			continue
+		}
+
+		expanded, err := expandLoadBalancerPolicies(raw.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		if len(expanded) == 0 {
+			continue
+		}
+
+		lbPort, err := strconv.ParseInt(newL["lb_port"].(string), 0, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		policies[lbPort] = expanded
+	}
+
+	return policies, nil
+}
+
+// Takes the result of flatmap.Expand for the policy attribute map of a
+// custom SSL negotiation policy and returns ELB API compatible
+// PolicyAttribute objects.
+func expandLoadBalancerPolicyAttributes(configured map[string]interface{}) []elb.PolicyAttribute {
+	attributes := make([]elb.PolicyAttribute, 0, len(configured))
+
+	for k, v := range configured {
+		attributes = append(attributes, elb.PolicyAttribute{
+			AttributeName:  k,
+			AttributeValue: fmt.Sprintf("%v", v),
+		})
+	}
+
+	return canonicalisePolicyAttributes(attributes)
+}
+
+type sortablePolicyAttributes []elb.PolicyAttribute
+
+func (s sortablePolicyAttributes) Len() int      { return len(s) }
+func (s sortablePolicyAttributes) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortablePolicyAttributes) Less(i, j int) bool {
+	return s[i].AttributeName < s[j].AttributeName
+}
+
+// canonicalisePolicyAttributes sorts a slice of policy attributes,
+// canonicaliseIPPerms-style, so that attribute ordering differences
+// between the configured policy and the one returned by the API don't
+// produce spurious plan diffs.
+func canonicalisePolicyAttributes(attrs []elb.PolicyAttribute) []elb.PolicyAttribute {
+	sort.Sort(sortablePolicyAttributes(attrs))
+	return attrs
+}
+
 type ipPermKey struct {
 	Protocol string
 	FromPort int
@@ -195,6 +302,38 @@ func flattenIPPerms(list []ec2.IPPerm) []map[string]interface{} {
 	return result
 }
 
+// Flattens an array of listeners into a list of primitives that
+// flatmap.Flatten() can handle, including any SSL negotiation policies
+// attached to a given listener port.
+func flattenListeners(list []elb.Listener, sslPolicies map[int64][]*sslNegotiationPolicy) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(list))
+
+	for _, i := range list {
+		l := map[string]interface{}{
+			"instance_port":     int(i.InstancePort),
+			"instance_protocol": i.InstanceProtocol,
+			"lb_port":           int(i.LoadBalancerPort),
+			"lb_protocol":       i.Protocol,
+		}
+
+		if i.SSLCertificateId != "" {
+			l["ssl_certificate_id"] = i.SSLCertificateId
+		}
+
+		if policies, ok := sslPolicies[i.LoadBalancerPort]; ok && len(policies) > 0 {
+			flattened := make([]map[string]interface{}, 0, len(policies))
+			for _, p := range policies {
+				flattened = append(flattened, map[string]interface{}{"name": p.Name})
+			}
+			l["ssl_negotiation_policies"] = flattened
+		}
+
+		result = append(result, l)
+	}
+
+	return result
+}
+
 // Flattens a health check into something that flatmap.Flatten()
 // can handle
 func flattenHealthCheck(check elb.HealthCheck) []map[string]interface{} {
@@ -0,0 +1,94 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bgentry/heroku-go"
+	"github.com/hashicorp/terraform/helper/multierror"
+)
+
+// type application is used to store all the details of a heroku app
+type application struct {
+	Id string // Id of the resource
+
+	App    *heroku.App       // The heroku application
+	Client *heroku.Client    // Client to interact with the heroku API
+	Vars   map[string]string // The vars on the application
+}
+
+// Updates the application to have the latest from remote
+func (a *application) Update() error {
+	var errs []error
+	var err error
+
+	a.App, err = a.Client.AppInfo(a.Id)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	a.Vars, err = retrieve_config_vars(a.Id, a.Client)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return &multierror.Error{Errors: errs}
+	}
+
+	return nil
+}
+
+// resource_heroku_app_retrieve looks up an app by name or UUID. It lives
+// here, rather than in resource_heroku_app.go, so that sibling resources
+// (add-ons, domains, drains, collaborators) can resolve the app they are
+// attached to without importing the app resource's CRUD code.
+func resource_heroku_app_retrieve(id string, client *heroku.Client) (*application, error) {
+	app := application{Id: id, Client: client}
+
+	err := app.Update()
+
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving app: %s", err)
+	}
+
+	return &app, nil
+}
+
+func retrieve_config_vars(id string, client *heroku.Client) (map[string]string, error) {
+	vars, err := client.ConfigVarInfo(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// Updates the config vars for from an expanded configuration.
+func update_config_vars(
+	id string,
+	client *heroku.Client,
+	o []interface{},
+	n []interface{}) error {
+	vars := make(map[string]*string)
+
+	for _, v := range o {
+		for k, _ := range v.(map[string]interface{}) {
+			vars[k] = nil
+		}
+	}
+	for _, v := range n {
+		for k, v := range v.(map[string]interface{}) {
+			val := v.(string)
+			vars[k] = &val
+		}
+	}
+
+	log.Printf("[INFO] Updating config vars: *%#v", vars)
+	if _, err := client.ConfigVarUpdate(id, vars); err != nil {
+		return fmt.Errorf("Error updating config vars: %s", err)
+	}
+
+	return nil
+}
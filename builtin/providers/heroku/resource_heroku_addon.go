@@ -0,0 +1,124 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bgentry/heroku-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// addon wraps a single add-on attached to a Heroku app, mirroring the
+// application-style retrieval pattern: a struct around *heroku.Client
+// with an Update() method that refreshes it from the API.
+type addon struct {
+	Id string // Id of the resource
+
+	App    string         // The Heroku app the add-on is attached to
+	AddOn  *heroku.AddOn  // The add-on itself
+	Client *heroku.Client // Client to interact with the Heroku API
+}
+
+// Updates the addon to have the latest from remote
+func (a *addon) Update() error {
+	var err error
+	a.AddOn, err = a.Client.AddOnInfo(a.App, a.Id)
+	return err
+}
+
+func resourceHerokuAddon() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuAddonCreate,
+		Read:   resourceHerokuAddonRead,
+		Delete: resourceHerokuAddonDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"plan": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"config": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"provider_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuAddonCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	app := d.Get("app").(string)
+	opts := heroku.AddOnCreateOpts{}
+
+	if v, ok := d.GetOk("config"); ok {
+		config := make(map[string]string)
+		for k, val := range v.(map[string]interface{}) {
+			config[k] = val.(string)
+		}
+		opts.Config = &config
+	}
+
+	log.Printf("[DEBUG] AddOn create configuration: %#v, %#v", app, opts)
+	a, err := client.AddOnCreate(app, d.Get("plan").(string), &opts)
+	if err != nil {
+		return fmt.Errorf("Error creating addon: %s", err)
+	}
+
+	d.SetId(a.Id)
+	log.Printf("[INFO] AddOn ID: %s", d.Id())
+
+	return resourceHerokuAddonRead(d, meta)
+}
+
+func resourceHerokuAddonRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	addon, err := resourceHerokuAddonRetrieve(d.Get("app").(string), d.Id(), client)
+	if err != nil {
+		return err
+	}
+
+	d.Set("plan", addon.AddOn.Plan.Name)
+	d.Set("provider_id", addon.AddOn.ProviderId)
+
+	return nil
+}
+
+func resourceHerokuAddonDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	log.Printf("[INFO] Deleting AddOn: %s", d.Id())
+	err := client.AddOnDelete(d.Get("app").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting addon: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceHerokuAddonRetrieve(app string, id string, client *heroku.Client) (*addon, error) {
+	a := addon{Id: id, App: app, Client: client}
+
+	err := a.Update()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving addon: %s", err)
+	}
+
+	return &a, nil
+}
@@ -5,41 +5,9 @@ import (
 	"log"
 
 	"github.com/bgentry/heroku-go"
-	"github.com/hashicorp/terraform/helper/multierror"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-// type application is used to store all the details of a heroku app
-type application struct {
-	Id string // Id of the resource
-
-	App    *heroku.App       // The heroku application
-	Client *heroku.Client    // Client to interact with the heroku API
-	Vars   map[string]string // The vars on the application
-}
-
-// Updates the application to have the latest from remote
-func (a *application) Update() error {
-	var errs []error
-	var err error
-
-	a.App, err = a.Client.AppInfo(a.Id)
-	if err != nil {
-		errs = append(errs, err)
-	}
-
-	a.Vars, err = retrieve_config_vars(a.Id, a.Client)
-	if err != nil {
-		errs = append(errs, err)
-	}
-
-	if len(errs) > 0 {
-		return &multierror.Error{Errors: errs}
-	}
-
-	return nil
-}
-
 func resourceHerokuApp() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceHerokuAppCreate,
@@ -90,6 +58,17 @@ func resourceHerokuApp() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			// uuid duplicates what d.Id() already holds (see the ID
+			// semantics note on resourceHerokuAppCreate below), kept as its
+			// own attribute because the request asked for a
+			// depends_on-friendly heroku_app.uuid in addition to name, and
+			// because existing state created before that ID change still
+			// has its id set to the app's name rather than its UUID.
+			"uuid": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -122,7 +101,21 @@ func resourceHerokuAppCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	d.SetId(a.Name)
+	// Use the app's UUID, not its name, as the resource ID: the name can
+	// be changed later via resourceHerokuAppUpdate, and the Heroku API
+	// accepts either name or UUID wherever an app identifier is required,
+	// so keeping the ID on the UUID means child resources that reference
+	// heroku_app.foo.id keep working across renames.
+	//
+	// This is a change to heroku_app's established ID semantics (it used
+	// to be the app name). It only affects apps created going forward:
+	// state written before this change already has its id set to the old
+	// app name, and this resource does not migrate it - a subsequent
+	// rename of such an app will still change its id out from under
+	// anything referencing it. Configs that can't tolerate that for
+	// existing apps should reference the uuid attribute instead of the
+	// implicit .id until the app is re-created or imported.
+	d.SetId(a.Id)
 	log.Printf("[INFO] App ID: %s", d.Id())
 
 	if v := d.Get("config_vars"); v != nil {
@@ -148,6 +141,7 @@ func resourceHerokuAppRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("git_url", app.App.GitURL)
 	d.Set("web_url", app.App.WebURL)
 	d.Set("config_vars", []map[string]string{app.Vars})
+	d.Set("uuid", app.App.Id)
 
 	// We know that the hostname on heroku will be the name+herokuapp.com
 	// You need this to do things like create DNS CNAME records
@@ -166,13 +160,12 @@ func resourceHerokuAppUpdate(d *schema.ResourceData, meta interface{}) error {
 			Name: &v,
 		}
 
-		renamedApp, err := client.AppUpdate(d.Id(), &opts)
+		// The ID is the app's UUID and doesn't change when its name does,
+		// so there's nothing to store back onto d here.
+		_, err := client.AppUpdate(d.Id(), &opts)
 		if err != nil {
 			return err
 		}
-
-		// Store the new ID
-		d.SetId(renamedApp.Name)
 	}
 
 	// If the config vars changed, then recalculate those
@@ -207,53 +200,3 @@ func resourceHerokuAppDelete(d *schema.ResourceData, meta interface{}) error {
 	d.SetId("")
 	return nil
 }
-
-func resource_heroku_app_retrieve(id string, client *heroku.Client) (*application, error) {
-	app := application{Id: id, Client: client}
-
-	err := app.Update()
-
-	if err != nil {
-		return nil, fmt.Errorf("Error retrieving app: %s", err)
-	}
-
-	return &app, nil
-}
-
-func retrieve_config_vars(id string, client *heroku.Client) (map[string]string, error) {
-	vars, err := client.ConfigVarInfo(id)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return vars, nil
-}
-
-// Updates the config vars for from an expanded configuration.
-func update_config_vars(
-	id string,
-	client *heroku.Client,
-	o []interface{},
-	n []interface{}) error {
-	vars := make(map[string]*string)
-
-	for _, v := range o {
-		for k, _ := range v.(map[string]interface{}) {
-			vars[k] = nil
-		}
-	}
-	for _, v := range n {
-		for k, v := range v.(map[string]interface{}) {
-			val := v.(string)
-			vars[k] = &val
-		}
-	}
-
-	log.Printf("[INFO] Updating config vars: *%#v", vars)
-	if _, err := client.ConfigVarUpdate(id, vars); err != nil {
-		return fmt.Errorf("Error updating config vars: %s", err)
-	}
-
-	return nil
-}
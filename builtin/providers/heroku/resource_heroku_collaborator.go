@@ -0,0 +1,105 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bgentry/heroku-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// collaborator wraps a single collaborator on a Heroku app, mirroring the
+// application-style retrieval pattern: a struct around *heroku.Client
+// with an Update() method that refreshes it from the API.
+type collaborator struct {
+	Id string // Id of the resource
+
+	App          string               // The Heroku app the collaborator is on
+	Collaborator *heroku.Collaborator // The collaborator itself
+	Client       *heroku.Client       // Client to interact with the Heroku API
+}
+
+// Updates the collaborator to have the latest from remote
+func (c *collaborator) Update() error {
+	var err error
+	c.Collaborator, err = c.Client.CollaboratorInfo(c.App, c.Id)
+	return err
+}
+
+func resourceHerokuCollaborator() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuCollaboratorCreate,
+		Read:   resourceHerokuCollaboratorRead,
+		Delete: resourceHerokuCollaboratorDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuCollaboratorCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	app := d.Get("app").(string)
+	email := d.Get("email").(string)
+	opts := heroku.CollaboratorCreateOpts{User: email}
+
+	log.Printf("[DEBUG] Collaborator create configuration: %#v, %#v", app, opts)
+	c, err := client.CollaboratorCreate(app, opts)
+	if err != nil {
+		return fmt.Errorf("Error creating collaborator: %s", err)
+	}
+
+	d.SetId(c.Id)
+	log.Printf("[INFO] Collaborator ID: %s", d.Id())
+
+	return resourceHerokuCollaboratorRead(d, meta)
+}
+
+func resourceHerokuCollaboratorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	c, err := resourceHerokuCollaboratorRetrieve(d.Get("app").(string), d.Id(), client)
+	if err != nil {
+		return err
+	}
+
+	d.Set("email", c.Collaborator.User.Email)
+
+	return nil
+}
+
+func resourceHerokuCollaboratorDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	log.Printf("[INFO] Deleting Collaborator: %s", d.Id())
+	err := client.CollaboratorDelete(d.Get("app").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting collaborator: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceHerokuCollaboratorRetrieve(app string, id string, client *heroku.Client) (*collaborator, error) {
+	c := collaborator{Id: id, App: app, Client: client}
+
+	err := c.Update()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving collaborator: %s", err)
+	}
+
+	return &c, nil
+}
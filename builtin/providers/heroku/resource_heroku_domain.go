@@ -0,0 +1,108 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bgentry/heroku-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// domain wraps a single custom domain attached to a Heroku app.
+type domain struct {
+	Id string // Id of the resource
+
+	App    string         // The Heroku app the domain is attached to
+	Domain *heroku.Domain // The domain itself
+	Client *heroku.Client // Client to interact with the Heroku API
+}
+
+// Updates the domain to have the latest from remote
+func (d *domain) Update() error {
+	var err error
+	d.Domain, err = d.Client.DomainInfo(d.App, d.Id)
+	return err
+}
+
+func resourceHerokuDomain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuDomainCreate,
+		Read:   resourceHerokuDomainRead,
+		Delete: resourceHerokuDomainDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"hostname": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"cname": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	app := d.Get("app").(string)
+	hostname := d.Get("hostname").(string)
+
+	log.Printf("[DEBUG] Domain create configuration: %#v, %#v", app, hostname)
+	dom, err := client.DomainCreate(app, hostname)
+	if err != nil {
+		return fmt.Errorf("Error creating domain: %s", err)
+	}
+
+	d.SetId(dom.Id)
+	log.Printf("[INFO] Domain ID: %s", d.Id())
+
+	return resourceHerokuDomainRead(d, meta)
+}
+
+func resourceHerokuDomainRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	dom, err := resourceHerokuDomainRetrieve(d.Get("app").(string), d.Id(), client)
+	if err != nil {
+		return err
+	}
+
+	d.Set("hostname", dom.Domain.Hostname)
+	d.Set("cname", dom.Domain.CName)
+
+	return nil
+}
+
+func resourceHerokuDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	log.Printf("[INFO] Deleting Domain: %s", d.Id())
+	err := client.DomainDelete(d.Get("app").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting domain: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceHerokuDomainRetrieve(app string, id string, client *heroku.Client) (*domain, error) {
+	dom := domain{Id: id, App: app, Client: client}
+
+	err := dom.Update()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving domain: %s", err)
+	}
+
+	return &dom, nil
+}
@@ -0,0 +1,110 @@
+package heroku
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bgentry/heroku-go"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// drain wraps a single log drain attached to a Heroku app, mirroring the
+// application-style retrieval pattern: a struct around *heroku.Client
+// with an Update() method that refreshes it from the API.
+type drain struct {
+	Id string // Id of the resource
+
+	App    string           // The Heroku app the drain is attached to
+	Drain  *heroku.LogDrain // The drain itself
+	Client *heroku.Client   // Client to interact with the Heroku API
+}
+
+// Updates the drain to have the latest from remote
+func (d *drain) Update() error {
+	var err error
+	d.Drain, err = d.Client.LogDrainInfo(d.App, d.Id)
+	return err
+}
+
+func resourceHerokuDrain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceHerokuDrainCreate,
+		Read:   resourceHerokuDrainRead,
+		Delete: resourceHerokuDrainDelete,
+
+		Schema: map[string]*schema.Schema{
+			"app": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"token": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceHerokuDrainCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	app := d.Get("app").(string)
+	url := d.Get("url").(string)
+
+	log.Printf("[DEBUG] Drain create configuration: %#v, %#v", app, url)
+	dr, err := client.LogDrainCreate(app, url)
+	if err != nil {
+		return fmt.Errorf("Error creating drain: %s", err)
+	}
+
+	d.SetId(dr.Id)
+	log.Printf("[INFO] Drain ID: %s", d.Id())
+
+	return resourceHerokuDrainRead(d, meta)
+}
+
+func resourceHerokuDrainRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	dr, err := resourceHerokuDrainRetrieve(d.Get("app").(string), d.Id(), client)
+	if err != nil {
+		return err
+	}
+
+	d.Set("url", dr.Drain.URL)
+	d.Set("token", dr.Drain.Token)
+
+	return nil
+}
+
+func resourceHerokuDrainDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*heroku.Client)
+
+	log.Printf("[INFO] Deleting Drain: %s", d.Id())
+	err := client.LogDrainDelete(d.Get("app").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting drain: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceHerokuDrainRetrieve(app string, id string, client *heroku.Client) (*drain, error) {
+	dr := drain{Id: id, App: app, Client: client}
+
+	err := dr.Update()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving drain: %s", err)
+	}
+
+	return &dr, nil
+}
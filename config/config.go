@@ -3,7 +3,9 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform/flatmap"
@@ -58,6 +60,11 @@ type Variable struct {
 	Name        string
 	Default     interface{}
 	Description string
+
+	// DeclaredType is the type given by an explicit `type = "..."` field
+	// on the variable block, overriding the type that would otherwise be
+	// inferred from Default. It is empty when no type was declared.
+	DeclaredType string
 }
 
 // Output is an output defined within the configuration. An output is
@@ -75,8 +82,38 @@ const (
 	VariableTypeUnknown VariableType = iota
 	VariableTypeString
 	VariableTypeMap
+	VariableTypeList
+	VariableTypeNumber
+	VariableTypeBool
 )
 
+// variableTypeNames maps the explicit `type = "..."` string a user can
+// write on a variable block to the VariableType it declares.
+var variableTypeNames = map[string]VariableType{
+	"string": VariableTypeString,
+	"map":    VariableTypeMap,
+	"list":   VariableTypeList,
+	"number": VariableTypeNumber,
+	"bool":   VariableTypeBool,
+}
+
+func (t VariableType) Printable() string {
+	switch t {
+	case VariableTypeString:
+		return "string"
+	case VariableTypeMap:
+		return "map"
+	case VariableTypeList:
+		return "list"
+	case VariableTypeNumber:
+		return "number"
+	case VariableTypeBool:
+		return "bool"
+	default:
+		return "unknown"
+	}
+}
+
 // ProviderConfigName returns the name of the provider configuration in
 // the given mapping that maps to the proper provider configuration
 // for this resource.
@@ -114,12 +151,27 @@ func (c *Config) Validate() error {
 
 	for _, v := range c.Variables {
 		if v.Type() == VariableTypeUnknown {
-			errs = append(errs, fmt.Errorf(
-				"Variable '%s': must be string or mapping",
-				v.Name))
+			if v.DeclaredType != "" {
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': unknown type '%s'",
+					v.Name, v.DeclaredType))
+			} else {
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': must be string, list, map, number or bool",
+					v.Name))
+			}
 			continue
 		}
 
+		if v.DeclaredType != "" && v.Default != nil {
+			if !defaultMatchesType(v.Default, v.Type()) {
+				errs = append(errs, fmt.Errorf(
+					"Variable '%s': default value does not match declared type '%s'",
+					v.Name, v.DeclaredType))
+				continue
+			}
+		}
+
 		interp := false
 		fn := func(i Interpolation) (string, error) {
 			interp = true
@@ -218,6 +270,17 @@ func (c *Config) Validate() error {
 					id))
 				continue
 			}
+
+			// A filter() applied to the reference must at least
+			// reference a field to select on.
+			if rv.Filter != nil && len(rv.Filter.Fields()) == 0 {
+				errs = append(errs, fmt.Errorf(
+					"%s: filter on '%s' must reference at least one field "+
+						"of %s",
+					source,
+					rv.FullKey(),
+					id))
+			}
 		}
 	}
 
@@ -333,12 +396,23 @@ func (v *Variable) DefaultsMap() map[string]string {
 	switch v.Type() {
 	case VariableTypeString:
 		return map[string]string{n: v.Default.(string)}
+	case VariableTypeNumber:
+		return map[string]string{n: strconv.FormatFloat(v.Default.(float64), 'f', -1, 64)}
+	case VariableTypeBool:
+		return map[string]string{n: strconv.FormatBool(v.Default.(bool))}
 	case VariableTypeMap:
 		result := flatmap.Flatten(map[string]interface{}{
 			n: v.Default.(map[string]string),
 		})
 		result[n] = v.Name
 
+		return result
+	case VariableTypeList:
+		result := flatmap.Flatten(map[string]interface{}{
+			n: v.Default.([]interface{}),
+		})
+		result[n+".#"] = strconv.Itoa(len(v.Default.([]interface{})))
+
 		return result
 	default:
 		return nil
@@ -365,23 +439,195 @@ func (v *Variable) Merge(v2 *Variable) *Variable {
 
 // Type returns the type of varialbe this is.
 func (v *Variable) Type() VariableType {
+	// An explicit `type = "..."` field always wins over inference.
+	if v.DeclaredType != "" {
+		if t, ok := variableTypeNames[v.DeclaredType]; ok {
+			return t
+		}
+
+		return VariableTypeUnknown
+	}
+
 	if v.Default == nil {
 		return VariableTypeString
 	}
 
+	// An exact Go type match is checked before any mapstructure.WeakDecode
+	// fallback below: WeakDecode's bool decoding accepts any nonzero
+	// number and its numeric decoding accepts bool, so deciding bool vs.
+	// number by trying WeakDecode first (in either order) silently
+	// misclassifies the other. A literal `default = 5` or `default =
+	// true` always carries its real Go type here, so switch on that
+	// directly instead of guessing from a weak decode.
+	switch d := v.Default.(type) {
+	case bool:
+		return VariableTypeBool
+	case int:
+		v.Default = float64(d)
+		return VariableTypeNumber
+	case int64:
+		v.Default = float64(d)
+		return VariableTypeNumber
+	case float64:
+		return VariableTypeNumber
+	case []interface{}:
+		return VariableTypeList
+	case map[string]string:
+		return VariableTypeMap
+	}
+
+	if _, isStr := v.Default.(string); !isStr {
+		var list []interface{}
+		if err := mapstructure.WeakDecode(v.Default, &list); err == nil {
+			v.Default = list
+			return VariableTypeList
+		}
+
+		var m map[string]string
+		if err := mapstructure.WeakDecode(v.Default, &m); err == nil {
+			v.Default = m
+			return VariableTypeMap
+		}
+	}
+
 	var strVal string
 	if err := mapstructure.WeakDecode(v.Default, &strVal); err == nil {
 		v.Default = strVal
 		return VariableTypeString
 	}
 
-	var m map[string]string
-	if err := mapstructure.WeakDecode(v.Default, &m); err == nil {
-		v.Default = m
-		return VariableTypeMap
+	return VariableTypeUnknown
+}
+
+// defaultMatchesType reports whether def's concrete Go type is the one an
+// explicit `type = "..."` declaration expects. It deliberately does not
+// go through Coerce: Coerce is built to parse loosely-typed CLI/tfvars
+// input (e.g. numeric strings), and its bool/number cases each fall back
+// to mapstructure.WeakDecode, which accepts any nonzero number as a bool
+// and a bool as 0/1 - exactly the cross-contamination that would let
+// `type = "bool" default = 5` pass validation silently.
+func defaultMatchesType(def interface{}, t VariableType) bool {
+	switch t {
+	case VariableTypeBool:
+		_, ok := def.(bool)
+		return ok
+
+	case VariableTypeNumber:
+		switch def.(type) {
+		case int, int64, float64:
+			return true
+		default:
+			return false
+		}
+
+	case VariableTypeString:
+		_, ok := def.(string)
+		return ok
+
+	case VariableTypeList:
+		_, ok := def.([]interface{})
+		return ok
+
+	case VariableTypeMap:
+		switch def.(type) {
+		case map[string]string, map[string]interface{}:
+			return true
+		default:
+			return false
+		}
+
+	default:
+		return false
 	}
+}
 
-	return VariableTypeUnknown
+// Coerce normalizes a raw, user-supplied value (from interpolation, CLI
+// `-var`, or a tfvars file) into the type this variable declares,
+// including parsing numeric strings and JSON-encoded lists. Callers that
+// already have a value of the right Go type may pass it through
+// unchanged; Coerce only converts when the raw value doesn't already
+// match.
+func (v *Variable) Coerce(raw interface{}) (interface{}, error) {
+	switch v.Type() {
+	case VariableTypeString:
+		var s string
+		if err := mapstructure.WeakDecode(raw, &s); err != nil {
+			return nil, fmt.Errorf(
+				"variable %s: cannot coerce %v to string", v.Name, raw)
+		}
+		return s, nil
+
+	case VariableTypeNumber:
+		if f, ok := raw.(float64); ok {
+			return f, nil
+		}
+		if s, ok := raw.(string); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"variable %s: cannot coerce %q to number", v.Name, s)
+			}
+			return f, nil
+		}
+
+		var f float64
+		if err := mapstructure.WeakDecode(raw, &f); err != nil {
+			return nil, fmt.Errorf(
+				"variable %s: cannot coerce %v to number", v.Name, raw)
+		}
+		return f, nil
+
+	case VariableTypeBool:
+		if b, ok := raw.(bool); ok {
+			return b, nil
+		}
+		if s, ok := raw.(string); ok {
+			b, err := strconv.ParseBool(strings.TrimSpace(s))
+			if err != nil {
+				return nil, fmt.Errorf(
+					"variable %s: cannot coerce %q to bool", v.Name, s)
+			}
+			return b, nil
+		}
+
+		var b bool
+		if err := mapstructure.WeakDecode(raw, &b); err != nil {
+			return nil, fmt.Errorf(
+				"variable %s: cannot coerce %v to bool", v.Name, raw)
+		}
+		return b, nil
+
+	case VariableTypeList:
+		if list, ok := raw.([]interface{}); ok {
+			return list, nil
+		}
+		if s, ok := raw.(string); ok {
+			var list []interface{}
+			if err := json.Unmarshal([]byte(s), &list); err != nil {
+				return nil, fmt.Errorf(
+					"variable %s: cannot coerce %q to list: %s", v.Name, s, err)
+			}
+			return list, nil
+		}
+
+		return nil, fmt.Errorf(
+			"variable %s: cannot coerce %v to list", v.Name, raw)
+
+	case VariableTypeMap:
+		if m, ok := raw.(map[string]string); ok {
+			return m, nil
+		}
+
+		var m map[string]string
+		if err := mapstructure.WeakDecode(raw, &m); err != nil {
+			return nil, fmt.Errorf(
+				"variable %s: cannot coerce %v to map", v.Name, raw)
+		}
+		return m, nil
+
+	default:
+		return raw, nil
+	}
 }
 
 func (v *Variable) mergerName() string {
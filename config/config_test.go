@@ -0,0 +1,109 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVariableType(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Default interface{}
+		Want    VariableType
+	}{
+		{"number", 5.0, VariableTypeNumber},
+		{"number as int", 5, VariableTypeNumber},
+		{"bool true", true, VariableTypeBool},
+		{"bool false", false, VariableTypeBool},
+		{"string", "foo", VariableTypeString},
+		{"list", []interface{}{"a", "b"}, VariableTypeList},
+		{"map", map[string]string{"a": "b"}, VariableTypeMap},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			v := &Variable{Default: tc.Default}
+			if got := v.Type(); got != tc.Want {
+				t.Fatalf("Type() = %s, want %s", got.Printable(), tc.Want.Printable())
+			}
+		})
+	}
+}
+
+func TestVariableType_numberDefaultSurvives(t *testing.T) {
+	// A plain numeric default must not be reclassified as a bool and
+	// overwritten in the process: mapstructure's weak bool decode
+	// accepts any nonzero number, so checking bool before number used to
+	// turn `default = 5` into `default = true`.
+	v := &Variable{Default: 5.0}
+
+	if got := v.Type(); got != VariableTypeNumber {
+		t.Fatalf("Type() = %s, want number", got.Printable())
+	}
+	if v.Default != 5.0 {
+		t.Fatalf("Default = %#v, want unchanged 5.0", v.Default)
+	}
+}
+
+func TestConfigValidate_declaredTypeMismatch(t *testing.T) {
+	// type = "bool" with a plain numeric default (or vice versa) must be
+	// rejected outright rather than silently accepted: Coerce is built
+	// for weakly-typed CLI/tfvars input and its bool/number cases each
+	// fall back to mapstructure.WeakDecode, which would let a numeric
+	// default like 5 pass as a declared bool.
+	cases := []struct {
+		Name         string
+		DeclaredType string
+		Default      interface{}
+		WantErr      bool
+	}{
+		{"bool declared, number default", "bool", 5.0, true},
+		{"number declared, bool default", "number", true, true},
+		{"bool declared, bool default", "bool", true, false},
+		{"number declared, number default", "number", 5.0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			c := &Config{
+				Variables: []*Variable{
+					{Name: "x", DeclaredType: tc.DeclaredType, Default: tc.Default},
+				},
+			}
+
+			err := c.Validate()
+			if tc.WantErr && err == nil {
+				t.Fatalf("Validate() = nil, want error for mismatched default")
+			}
+			if !tc.WantErr && err != nil {
+				t.Fatalf("Validate() = %s, want nil", err)
+			}
+		})
+	}
+}
+
+func TestVariableCoerce(t *testing.T) {
+	cases := []struct {
+		Name string
+		Var  *Variable
+		Raw  interface{}
+		Want interface{}
+	}{
+		{"number from string", &Variable{Default: 0.0}, "42", 42.0},
+		{"number passthrough", &Variable{Default: 0.0}, 42.0, 42.0},
+		{"bool from string", &Variable{Default: false}, "true", true},
+		{"string passthrough", &Variable{Default: "x"}, "y", "y"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := tc.Var.Coerce(tc.Raw)
+			if err != nil {
+				t.Fatalf("Coerce() error = %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Fatalf("Coerce() = %#v, want %#v", got, tc.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FilterExpr is a parsed filter expression, as used by the `filter(...)`
+// interpolation function to select a subset of a multi-count resource's
+// elements, e.g.:
+//
+//	${aws_instance.web.*.id | filter("Tags.Env == \"prod\" && Port >= 80")}
+//
+// A FilterExpr is evaluated once per candidate element against that
+// element's flattened attribute map (the same shape flatmap.Flatten
+// produces), and elements for which it evaluates to true are kept.
+type FilterExpr struct {
+	Raw  string
+	root filterNode
+}
+
+// ParseFilter parses a filter expression and returns the FilterExpr that
+// can later be evaluated against candidate elements.
+func ParseFilter(raw string) (*FilterExpr, error) {
+	toks, err := filterLex(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: toks}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("filter: unexpected token %q", p.tokens[p.pos].text)
+	}
+
+	return &FilterExpr{Raw: raw, root: node}, nil
+}
+
+// Fields returns the set of dotted field paths (e.g. "Tags.Env") the
+// filter expression references. Config.Validate uses this to reject a
+// filter that references no field at all; it does not check the paths
+// against the attributes of the target resource type.
+func (f *FilterExpr) Fields() []string {
+	var fields []string
+	var walk func(n filterNode)
+	walk = func(n filterNode) {
+		switch t := n.(type) {
+		case *filterFieldNode:
+			fields = append(fields, t.path)
+		case *filterBinaryNode:
+			walk(t.left)
+			walk(t.right)
+		case *filterUnaryNode:
+			walk(t.expr)
+		}
+	}
+	walk(f.root)
+	return fields
+}
+
+// Apply evaluates the filter expression against each element and returns
+// the subset that satisfies it. Each element is the flattened attribute
+// map of one candidate resource, keyed the way flatmap.Flatten keys a
+// single resource's attributes (e.g. "Tags.Env", "Port").
+func (f *FilterExpr) Apply(elements []map[string]string) ([]map[string]string, error) {
+	result := make([]map[string]string, 0, len(elements))
+	for _, el := range elements {
+		ok, err := f.root.eval(el)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, el)
+		}
+	}
+
+	return result, nil
+}
+
+// filterValue is the dynamic result of evaluating part of a filter
+// expression: either a boolean (the top-level result of a comparison or
+// boolean operator) or a scalar operand (string, number, or bool) used
+// while comparing two sides of an expression.
+type filterValue struct {
+	boolVal   bool
+	isBool    bool
+	strVal    string
+	isStr     bool
+	numVal    float64
+	isNum     bool
+	isMissing bool
+}
+
+type filterNode interface {
+	eval(attrs map[string]string) (bool, error)
+}
+
+type filterFieldNode struct {
+	path string
+}
+
+type filterLiteralNode struct {
+	val filterValue
+}
+
+type filterUnaryNode struct {
+	op   string
+	expr filterNode
+}
+
+type filterBinaryNode struct {
+	op    string
+	left  filterNode
+	right filterNode
+}
+
+// operandValue evaluates a filterNode to a filterValue rather than a
+// bool, for use on either side of a comparison.
+func operandValue(n filterNode, attrs map[string]string) (filterValue, error) {
+	switch t := n.(type) {
+	case *filterFieldNode:
+		raw, ok := attrs[t.path]
+		if !ok {
+			return filterValue{isMissing: true}, nil
+		}
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return filterValue{numVal: f, isNum: true}, nil
+		}
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return filterValue{boolVal: b, isBool: true}, nil
+		}
+		return filterValue{strVal: raw, isStr: true}, nil
+	case *filterLiteralNode:
+		return t.val, nil
+	default:
+		b, err := n.eval(attrs)
+		return filterValue{boolVal: b, isBool: true}, err
+	}
+}
+
+func (n *filterFieldNode) eval(attrs map[string]string) (bool, error) {
+	v, err := operandValue(n, attrs)
+	if err != nil {
+		return false, err
+	}
+	return v.isBool && v.boolVal, nil
+}
+
+func (n *filterLiteralNode) eval(attrs map[string]string) (bool, error) {
+	return n.val.isBool && n.val.boolVal, nil
+}
+
+func (n *filterUnaryNode) eval(attrs map[string]string) (bool, error) {
+	switch n.op {
+	case "!":
+		v, err := n.expr.eval(attrs)
+		if err != nil {
+			return false, err
+		}
+		return !v, nil
+	default:
+		return false, fmt.Errorf("filter: unknown unary operator %q", n.op)
+	}
+}
+
+func (n *filterBinaryNode) eval(attrs map[string]string) (bool, error) {
+	switch n.op {
+	case "&&":
+		l, err := n.left.eval(attrs)
+		if err != nil || !l {
+			return false, err
+		}
+		return n.right.eval(attrs)
+	case "||":
+		l, err := n.left.eval(attrs)
+		if err != nil || l {
+			return l, err
+		}
+		return n.right.eval(attrs)
+	}
+
+	lv, err := operandValue(n.left, attrs)
+	if err != nil {
+		return false, err
+	}
+	rv, err := operandValue(n.right, attrs)
+	if err != nil {
+		return false, err
+	}
+
+	if lv.isMissing || rv.isMissing {
+		return false, nil
+	}
+
+	switch n.op {
+	case "matches":
+		if !lv.isStr {
+			return false, fmt.Errorf("filter: left side of 'matches' must be a string")
+		}
+		pattern := rv.strVal
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("filter: invalid regexp %q: %s", pattern, err)
+		}
+		return re.MatchString(lv.strVal), nil
+	case "==", "!=", "<", "<=", ">", ">=":
+		return compareFilterValues(n.op, lv, rv)
+	default:
+		return false, fmt.Errorf("filter: unknown operator %q", n.op)
+	}
+}
+
+func compareFilterValues(op string, l, r filterValue) (bool, error) {
+	var cmp int
+	switch {
+	case l.isNum && r.isNum:
+		switch {
+		case l.numVal < r.numVal:
+			cmp = -1
+		case l.numVal > r.numVal:
+			cmp = 1
+		default:
+			cmp = 0
+		}
+	case l.isStr || r.isStr:
+		cmp = strings.Compare(filterValueString(l), filterValueString(r))
+	case l.isBool && r.isBool:
+		if l.boolVal == r.boolVal {
+			cmp = 0
+		} else if !l.boolVal && r.boolVal {
+			cmp = -1
+		} else {
+			cmp = 1
+		}
+	default:
+		return false, fmt.Errorf("filter: cannot compare values of differing types")
+	}
+
+	switch op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+
+	return false, fmt.Errorf("filter: unknown comparison operator %q", op)
+}
+
+func filterValueString(v filterValue) string {
+	switch {
+	case v.isStr:
+		return v.strVal
+	case v.isNum:
+		return strconv.FormatFloat(v.numVal, 'f', -1, 64)
+	case v.isBool:
+		return strconv.FormatBool(v.boolVal)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filterToken is a single lexical token in a filter expression.
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+type filterTokenKind int
+
+const (
+	filterTokEOF filterTokenKind = iota
+	filterTokIdent
+	filterTokString
+	filterTokNumber
+	filterTokBool
+	filterTokOp
+	filterTokLParen
+	filterTokRParen
+)
+
+var filterOperators = []string{
+	"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!",
+}
+
+// filterLex tokenizes a filter expression.
+func filterLex(src string) ([]filterToken, error) {
+	var toks []filterToken
+
+	runes := []rune(src)
+	i := 0
+	for i < len(runes) {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '(':
+			toks = append(toks, filterToken{filterTokLParen, "("})
+			i++
+
+		case c == ')':
+			toks = append(toks, filterToken{filterTokRParen, ")"})
+			i++
+
+		case c == '"':
+			j := i + 1
+			var sb strings.Builder
+			closed := false
+			for j < len(runes) {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					sb.WriteRune(runes[j+1])
+					j += 2
+					continue
+				}
+				if runes[j] == '"' {
+					closed = true
+					break
+				}
+				sb.WriteRune(runes[j])
+				j++
+			}
+			if !closed {
+				return nil, fmt.Errorf("filter: unterminated string literal")
+			}
+			toks = append(toks, filterToken{filterTokString, sb.String()})
+			i = j + 1
+
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			toks = append(toks, filterToken{filterTokNumber, string(runes[i:j])})
+			i = j
+
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			word := string(runes[i:j])
+			switch word {
+			case "true", "false":
+				toks = append(toks, filterToken{filterTokBool, word})
+			case "matches":
+				toks = append(toks, filterToken{filterTokOp, word})
+			default:
+				toks = append(toks, filterToken{filterTokIdent, word})
+			}
+			i = j
+
+		default:
+			matched := false
+			for _, op := range filterOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					toks = append(toks, filterToken{filterTokOp, op})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("filter: unexpected character %q", c)
+			}
+		}
+	}
+
+	return toks, nil
+}
+
+// filterParser is a simple recursive-descent parser over the token
+// stream produced by filterLex. Precedence, low to high: || , && , !
+// (prefix), comparisons (== != < <= > >= matches).
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	if p.pos >= len(p.tokens) {
+		return filterToken{kind: filterTokEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *filterParser) parseExpr() (filterNode, error) {
+	return p.parseOr()
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == filterTokOp && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinaryNode{op: "||", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == filterTokOp && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterBinaryNode{op: "&&", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.peek().kind == filterTokOp && p.peek().text == "!" {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterUnaryNode{op: "!", expr: expr}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind == filterTokOp {
+		switch p.peek().text {
+		case "==", "!=", "<", "<=", ">", ">=", "matches":
+			op := p.next().text
+			right, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			return &filterBinaryNode{op: op, left: left, right: right}, nil
+		}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parsePrimary() (filterNode, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case filterTokLParen:
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != filterTokRParen {
+			return nil, fmt.Errorf("filter: expected ')'")
+		}
+		p.next()
+		return expr, nil
+
+	case filterTokIdent:
+		p.next()
+		return &filterFieldNode{path: tok.text}, nil
+
+	case filterTokString:
+		p.next()
+		return &filterLiteralNode{val: filterValue{strVal: tok.text, isStr: true}}, nil
+
+	case filterTokNumber:
+		p.next()
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("filter: invalid number %q", tok.text)
+		}
+		return &filterLiteralNode{val: filterValue{numVal: f, isNum: true}}, nil
+
+	case filterTokBool:
+		p.next()
+		return &filterLiteralNode{val: filterValue{boolVal: tok.text == "true", isBool: true}}, nil
+
+	default:
+		return nil, fmt.Errorf("filter: unexpected token %q", tok.text)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import "testing"
+
+func TestFilterExpr_apply(t *testing.T) {
+	elements := []map[string]string{
+		{"Tags.Env": "prod", "Port": "80"},
+		{"Tags.Env": "dev", "Port": "8080"},
+		{"Tags.Env": "prod", "Port": "22"},
+	}
+
+	cases := []struct {
+		Name string
+		Expr string
+		Want int
+	}{
+		{"equality", `Tags.Env == "prod"`, 2},
+		{"and", `Tags.Env == "prod" && Port >= 80`, 1},
+		{"or", `Tags.Env == "dev" || Port == 22`, 2},
+		{"not", `!(Tags.Env == "prod")`, 1},
+		{"matches", `Tags.Env matches "^p"`, 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			f, err := ParseFilter(tc.Expr)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error = %s", tc.Expr, err)
+			}
+
+			got, err := f.Apply(elements)
+			if err != nil {
+				t.Fatalf("Apply() error = %s", err)
+			}
+			if len(got) != tc.Want {
+				t.Fatalf("Apply() returned %d elements, want %d", len(got), tc.Want)
+			}
+		})
+	}
+}
+
+func TestFilterExpr_fields(t *testing.T) {
+	f, err := ParseFilter(`Tags.Env == "prod" && Port >= 80`)
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %s", err)
+	}
+
+	fields := f.Fields()
+	if len(fields) != 2 {
+		t.Fatalf("Fields() = %v, want 2 entries", fields)
+	}
+}
+
+func TestNewResourceVariable_filter(t *testing.T) {
+	rv, err := NewResourceVariable(`aws_instance.web.*.id | filter("Tags.Env == \"prod\"")`)
+	if err != nil {
+		t.Fatalf("NewResourceVariable() error = %s", err)
+	}
+
+	if rv.Type != "aws_instance" || rv.Name != "web" || rv.Field != "id" {
+		t.Fatalf("unexpected parse: %#v", rv)
+	}
+	if !rv.Multi {
+		t.Fatalf("expected Multi to be true")
+	}
+	if rv.Filter == nil {
+		t.Fatalf("expected Filter to be set")
+	}
+
+	elements := []map[string]string{
+		{"Tags.Env": "prod"},
+		{"Tags.Env": "dev"},
+	}
+	got, err := rv.ApplyFilter(elements)
+	if err != nil {
+		t.Fatalf("ApplyFilter() error = %s", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("ApplyFilter() returned %d elements, want 1", len(got))
+	}
+}
+
+func TestNewResourceVariable_filterRequiresMulti(t *testing.T) {
+	_, err := NewResourceVariable(`aws_instance.web.id | filter("Tags.Env == \"prod\"")`)
+	if err == nil {
+		t.Fatalf("expected error applying filter() to a non-multi reference")
+	}
+}
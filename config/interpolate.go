@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InterpolatedVariable is a variable reference within an interpolation.
+type InterpolatedVariable interface {
+	FullKey() string
+}
+
+// ResourceVariable is an InterpolatedVariable that references a field on
+// another resource, e.g. "${aws_instance.web.id}". A multi-count
+// reference can additionally be narrowed with a filter() pipeline stage,
+// e.g. "${aws_instance.web.*.id | filter(\"Tags.Env == \\\"prod\\\"\")}".
+type ResourceVariable struct {
+	Type  string // Resource type, e.g. "aws_instance"
+	Name  string // Resource name, e.g. "web"
+	Field string // Field being referenced, e.g. "id"
+
+	Multi bool // True if the "*" multi-reference syntax was used
+	Index int  // Index into a multi-reference; -1 means "all"
+
+	// Filter narrows a multi-reference down to the elements that satisfy
+	// a filter() expression. It is nil unless the interpolation key had
+	// a trailing `| filter("...")` stage.
+	Filter *FilterExpr
+
+	key string
+}
+
+// NewResourceVariable parses a raw interpolation key of the form
+// "type.name.field", "type.name.N.field", or "type.name.*.field" into a
+// ResourceVariable. An optional trailing `| filter("...")` pipeline
+// stage is parsed with ParseFilter and attached as Filter.
+func NewResourceVariable(key string) (*ResourceVariable, error) {
+	raw := key
+
+	var filter *FilterExpr
+	if idx := strings.Index(raw, "|"); idx >= 0 {
+		expr, err := parseFilterStage(raw[idx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter in %q: %s", key, err)
+		}
+
+		raw = strings.TrimSpace(raw[:idx])
+		filter = expr
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf(
+			"key must be in the format 'type.name.field': %s", key)
+	}
+
+	multi := false
+	index := -1
+	field := parts[len(parts)-1]
+	name := parts[1]
+
+	if len(parts) == 4 {
+		multi = true
+		if parts[2] != "*" {
+			i, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf(
+					"%s: expected number or '*' for resource index", key)
+			}
+			index = i
+		}
+	}
+
+	if filter != nil && !multi {
+		return nil, fmt.Errorf(
+			"%s: filter() can only be applied to a multi-count reference (type.name.*.field)", key)
+	}
+
+	return &ResourceVariable{
+		Type:   parts[0],
+		Name:   name,
+		Field:  field,
+		Multi:  multi,
+		Index:  index,
+		Filter: filter,
+	}, nil
+}
+
+// parseFilterStage recognizes the `filter("...")` pipeline stage syntax
+// and hands the quoted expression to ParseFilter.
+func parseFilterStage(stage string) (*FilterExpr, error) {
+	stage = strings.TrimSpace(stage)
+
+	const prefix = "filter("
+	if !strings.HasPrefix(stage, prefix) || !strings.HasSuffix(stage, ")") {
+		return nil, fmt.Errorf("unsupported interpolation pipeline stage: %s", stage)
+	}
+
+	arg := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(stage, prefix), ")"))
+
+	unquoted, err := strconv.Unquote(arg)
+	if err != nil {
+		return nil, fmt.Errorf("filter argument must be a quoted string: %s", arg)
+	}
+
+	return ParseFilter(unquoted)
+}
+
+// FullKey returns the original dotted key this variable was parsed from.
+// Config.Validate uses it to name the reference in its "unknown
+// resource", "must specify index" and filter error messages.
+func (v *ResourceVariable) FullKey() string {
+	if v.key == "" {
+		multiSuffix := ""
+		if v.Multi {
+			if v.Index == -1 {
+				multiSuffix = ".*"
+			} else {
+				multiSuffix = fmt.Sprintf(".%d", v.Index)
+			}
+		}
+		v.key = fmt.Sprintf("%s.%s%s.%s", v.Type, v.Name, multiSuffix, v.Field)
+	}
+
+	return v.key
+}
+
+// ApplyFilter narrows a set of flattened multi-resource elements (one
+// map per instance, in the shape flatmap.Flatten produces) down to those
+// that satisfy v.Filter. The interpolation walker calls this, after it
+// has resolved the multi-count reference into its candidate elements but
+// before substituting the result into the template, so a plain
+// "type.name.*.field" reference with no filter() stage is unaffected.
+func (v *ResourceVariable) ApplyFilter(elements []map[string]string) ([]map[string]string, error) {
+	if v.Filter == nil {
+		return elements, nil
+	}
+
+	return v.Filter.Apply(elements)
+}
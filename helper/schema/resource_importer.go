@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// ResourceImporter defines how a *Resource is imported into Terraform
+// state by `terraform import`, given nothing but an ID.
+type ResourceImporter struct {
+	// State is called to turn an ID into one or more ResourceData values
+	// ready to be refreshed into state.
+	State StateFunc
+}
+
+// StateFunc is the function a ResourceImporter.State calls to import a
+// resource. It returns one *ResourceData per resource that should enter
+// state as a result of the import, tagged with SetType so
+// Provider.ImportState knows which entry in ResourcesMap owns each one.
+// This lets a single import seed more than one resource, e.g. importing a
+// VPC that also yields its subnets.
+type StateFunc func(*ResourceData, interface{}) ([]*ResourceData, error)
+
+// ImportState implements the terraform.ResourceProvider import surface: it
+// looks up t in ResourcesMap, seeds a fresh ResourceData with id, and runs
+// the resource's importer. Every *ResourceData the importer returns must
+// be tagged (via SetType) with a type that also exists in ResourcesMap;
+// otherwise Terraform would have no Resource to Refresh it with.
+func (p *Provider) ImportState(t string, id string) ([]*terraform.ResourceState, error) {
+	r, ok := p.ResourcesMap[t]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource type: %s", t)
+	}
+
+	if r.Importer == nil {
+		return nil, fmt.Errorf("resource %s doesn't support import", t)
+	}
+
+	data, err := schemaMap(r.Schema).Data(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	data.SetId(id)
+	data.SetType(t)
+
+	imported, err := r.Importer.State(data, p.meta)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*terraform.ResourceState, 0, len(imported))
+	for _, d := range imported {
+		importedType := d.Type()
+		ir, ok := p.ResourcesMap[importedType]
+		if !ok {
+			return nil, fmt.Errorf(
+				"resource %s imported a state of unknown type %s",
+				t, importedType)
+		}
+
+		state, err := ir.Refresh(d.State(), p.meta)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, state)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package schema
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeoutKey identifies one of the deadlines a *Resource can declare in its
+// Timeouts block, for use with ResourceData.Timeout.
+type TimeoutKey string
+
+const (
+	TimeoutCreate  TimeoutKey = "create"
+	TimeoutRead    TimeoutKey = "read"
+	TimeoutUpdate  TimeoutKey = "update"
+	TimeoutDelete  TimeoutKey = "delete"
+	TimeoutDefault TimeoutKey = "default"
+)
+
+// TimeoutsConfigKey is the reserved top-level config block name
+// ("timeouts { create = \"30m\" ... }"). A *Resource whose own Schema
+// declares this key fails InternalValidate, since it would collide with
+// the block. Provider.ValidateResource does not special-case a block of
+// this name; it is validated against the Schema like any other key.
+const TimeoutsConfigKey = "timeouts"
+
+// ResourceTimeout holds the per-operation deadlines a *Resource declares.
+// A zero value for an operation means "no explicit timeout"; Default is
+// used as a fallback for any operation left unset.
+type ResourceTimeout struct {
+	Create  *time.Duration
+	Read    *time.Duration
+	Update  *time.Duration
+	Delete  *time.Duration
+	Default *time.Duration
+}
+
+// DurationForKey returns the deadline configured for key, falling back to
+// Default, or false if neither is set.
+func (t *ResourceTimeout) DurationForKey(key TimeoutKey) (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	var d *time.Duration
+	switch key {
+	case TimeoutCreate:
+		d = t.Create
+	case TimeoutRead:
+		d = t.Read
+	case TimeoutUpdate:
+		d = t.Update
+	case TimeoutDelete:
+		d = t.Delete
+	case TimeoutDefault:
+		d = t.Default
+	default:
+		return 0, false
+	}
+
+	if d == nil {
+		d = t.Default
+	}
+	if d == nil {
+		return 0, false
+	}
+
+	return *d, true
+}
+
+// validateTimeouts rejects a resource whose own Schema shadows the
+// reserved "timeouts" config block name. This is the only check made on
+// that name: Provider.Validate and ValidateResource do not recognize a
+// timeouts block in config.
+func validateTimeouts(r *Resource) error {
+	if _, ok := r.Schema[TimeoutsConfigKey]; ok {
+		return fmt.Errorf(
+			"%s is a reserved block name and cannot be used as a resource attribute",
+			TimeoutsConfigKey)
+	}
+
+	return nil
+}